@@ -0,0 +1,31 @@
+package etcdstorage
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+)
+
+const rootRoleName = "root"
+
+// requireRoot verifies that username has been granted the root role via
+// etcd's auth API, returning a clear error rather than letting
+// misconfigured RBAC surface as an opaque permission-denied error on the
+// first Put.
+func (s *Service) requireRoot(username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	resp, err := s.etcdClient.UserGet(ctx, username)
+	if err != nil {
+		return microerror.Maskf(invalidConfigError, "could not verify role of user %q: %s", username, err)
+	}
+
+	for _, role := range resp.Roles {
+		if role == rootRoleName {
+			return nil
+		}
+	}
+
+	return microerror.Maskf(invalidConfigError, "user %q does not have the %q role", username, rootRoleName)
+}
@@ -0,0 +1,77 @@
+package etcdstorage
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/microstorage"
+)
+
+// CompareAndSwap atomically replaces the value stored at key with newValue,
+// but only if the current value equals prevValue. It returns a
+// compareFailedError (see IsCompareFailed) if the current value does not
+// match, and a microstorage.NotFoundError if the key does not exist.
+func (s *Service) CompareAndSwap(ctx context.Context, key, prevValue, newValue string) error {
+	key, err := s.sanitizeKey(key)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.keyClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value(key), "=", prevValue)).
+		Then(clientv3.OpPut(key, newValue)).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !resp.Succeeded {
+		return microerror.Mask(compareOrNotFoundError(key, resp))
+	}
+
+	return nil
+}
+
+// CompareAndDelete atomically deletes key, but only if its current value
+// equals prevValue. It returns a compareFailedError (see IsCompareFailed) if
+// the current value does not match, and a microstorage.NotFoundError if the
+// key does not exist.
+func (s *Service) CompareAndDelete(ctx context.Context, key, prevValue string) error {
+	key, err := s.sanitizeKey(key)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.keyClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value(key), "=", prevValue)).
+		Then(clientv3.OpDelete(key)).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !resp.Succeeded {
+		return microerror.Mask(compareOrNotFoundError(key, resp))
+	}
+
+	return nil
+}
+
+// compareOrNotFoundError inspects the Else branch of a failed compare-and-*
+// transaction to tell an absent key apart from a value mismatch.
+func compareOrNotFoundError(key string, resp *clientv3.TxnResponse) error {
+	if resp.Responses[0].GetResponseRange().Count == 0 {
+		return microerror.Maskf(microstorage.NotFoundError, key)
+	}
+	return microerror.Maskf(compareFailedError, key)
+}
@@ -0,0 +1,42 @@
+package etcdstorage
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+
+	"github.com/giantswarm/microstorage"
+)
+
+func txnResponseWithRangeCount(count int64) *clientv3.TxnResponse {
+	return &clientv3.TxnResponse{
+		Responses: []*etcdserverpb.ResponseOp{
+			{
+				Response: &etcdserverpb.ResponseOp_ResponseRange{
+					ResponseRange: &etcdserverpb.RangeResponse{Count: count},
+				},
+			},
+		},
+	}
+}
+
+func TestCompareOrNotFoundError_KeyAbsent(t *testing.T) {
+	resp := txnResponseWithRangeCount(0)
+
+	err := compareOrNotFoundError("/foo", resp)
+
+	if !microstorage.IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got %#v", err)
+	}
+}
+
+func TestCompareOrNotFoundError_ValueMismatch(t *testing.T) {
+	resp := txnResponseWithRangeCount(1)
+
+	err := compareOrNotFoundError("/foo", resp)
+
+	if !IsCompareFailed(err) {
+		t.Fatalf("expected a compareFailedError, got %#v", err)
+	}
+}
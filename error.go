@@ -0,0 +1,33 @@
+package etcdstorage
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var compareFailedError = microerror.New("compare failed")
+
+// IsCompareFailed asserts compareFailedError.
+func IsCompareFailed(err error) bool {
+	return microerror.Cause(err) == compareFailedError
+}
+
+var invalidTTLError = microerror.New("invalid ttl")
+
+// IsInvalidTTL asserts invalidTTLError.
+func IsInvalidTTL(err error) bool {
+	return microerror.Cause(err) == invalidTTLError
+}
+
+var invalidConfigError = microerror.New("invalid config")
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var multipleValuesError = microerror.New("multiple values")
+
+// IsMultipleValues asserts multipleValuesError.
+func IsMultipleValues(err error) bool {
+	return microerror.Cause(err) == multipleValuesError
+}
@@ -2,9 +2,11 @@ package etcdstorage
 
 import (
 	"context"
+	"crypto/tls"
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/microstorage"
@@ -18,6 +20,27 @@ type Config struct {
 	// Settings.
 	Prefix  string
 	Timeout time.Duration
+
+	// Endpoints are the etcd cluster member addresses used to construct a
+	// client when EtcdClient is not provided.
+	Endpoints []string
+	// Username and Password authenticate the constructed client against
+	// etcd's RBAC. They are not used for authentication when EtcdClient is
+	// provided; Username is still checked by RequireRoot in that case.
+	Username string
+	Password string
+	// CAFile, CertFile and KeyFile configure TLS for the connection to
+	// etcd. They are ignored when TLSConfig is set or EtcdClient is
+	// provided.
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	// TLSConfig, when set, takes precedence over CAFile/CertFile/KeyFile.
+	TLSConfig *tls.Config
+	// RequireRoot verifies at construction time that Username has been
+	// granted the root role, so that misconfigured RBAC surfaces as a
+	// clear error here instead of an opaque failure on the first Put.
+	RequireRoot bool
 }
 
 // DefaultConfig provides a default configuration to create a new etcd service
@@ -25,7 +48,7 @@ type Config struct {
 func DefaultConfig() Config {
 	return Config{
 		// Dependencies.
-		EtcdClient: nil, // Required.
+		EtcdClient: nil, // Required, unless Endpoints is set.
 
 		// Settings.
 		Prefix:  "",
@@ -37,7 +60,15 @@ func DefaultConfig() Config {
 func New(config Config) (*Service, error) {
 	// Dependencies.
 	if config.EtcdClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "etcd client must not be empty")
+		if len(config.Endpoints) == 0 {
+			return nil, microerror.Maskf(invalidConfigError, "etcd client or endpoints must not be empty")
+		}
+
+		etcdClient, err := newEtcdClient(config)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		config.EtcdClient = etcdClient
 	}
 	if config.Prefix != "" {
 		p, err := microstorage.SanitizeKey(config.Prefix)
@@ -52,23 +83,68 @@ func New(config Config) (*Service, error) {
 		etcdClient: config.EtcdClient,
 
 		// Internals.
-		keyClient: clientv3.NewKV(config.EtcdClient),
+		keyClient:   clientv3.NewKV(config.EtcdClient),
+		watchClient: clientv3.NewWatcher(config.EtcdClient),
 
 		// Settings.
 		prefix:  config.Prefix,
 		timeout: config.Timeout,
 	}
 
+	if config.RequireRoot {
+		if config.Username == "" {
+			return nil, microerror.Maskf(invalidConfigError, "username must be set when RequireRoot is true")
+		}
+
+		err := newService.requireRoot(config.Username)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	return newService, nil
 }
 
+// newEtcdClient constructs a clientv3.Client from endpoint, credential and
+// TLS settings for callers that do not provide their own EtcdClient.
+func newEtcdClient(config Config) (*clientv3.Client, error) {
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil && (config.CAFile != "" || config.CertFile != "" || config.KeyFile != "") {
+		tlsInfo := transport.TLSInfo{
+			CertFile:      config.CertFile,
+			KeyFile:       config.KeyFile,
+			TrustedCAFile: config.CAFile,
+		}
+
+		var err error
+		tlsConfig, err = tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "TLS config must be valid: %s", err)
+		}
+	}
+
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   config.Endpoints,
+		DialTimeout: config.Timeout,
+		Username:    config.Username,
+		Password:    config.Password,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "etcd client must be created: %s", err)
+	}
+
+	return etcdClient, nil
+}
+
 // Service is the etcd service.
 type Service struct {
 	// Dependencies.
 	etcdClient *clientv3.Client
 
 	// Internals.
-	keyClient clientv3.KV
+	keyClient   clientv3.KV
+	watchClient clientv3.Watcher
 
 	// Settings.
 	prefix  string
@@ -151,61 +227,38 @@ func (s *Service) Exists(ctx context.Context, key string) (bool, error) {
 	return true, nil
 }
 
+// List lists all keys stored directly underneath key. It fetches the result
+// in pages of defaultListPageLimit so that listing a large key space never
+// asks etcd for a single response that could exceed its size limit.
 func (s *Service) List(ctx context.Context, key string) ([]string, error) {
-	var err error
-
-	key, err = s.sanitizeListKey(key)
-	if err != nil {
-		return nil, microerror.Mask(err)
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	defer cancel()
-
-	opts := []clientv3.OpOption{
-		clientv3.WithKeysOnly(),
-		clientv3.WithPrefix(),
-	}
-
-	var res *clientv3.GetResponse
-	res, err = s.keyClient.Get(ctx, key, opts...)
+	key, err := s.sanitizeListKey(key)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
-	if res.Count == 0 {
-		return nil, microerror.Maskf(microstorage.NotFoundError, key)
-	}
-
-	// Special case.
-	if key == "/" {
-		var list []string
-		for _, kv := range res.Kvs {
-			// Skip the leading slash '/'.
-			k := string(kv.Key)[1:]
-			list = append(list, k)
-		}
-		return list, nil
-	}
-
 	var list []string
+	var found bool
 
-	i := len(key)
-	for _, kv := range res.Kvs {
-		k := string(kv.Key)
+	startKey := key
+	for {
+		res, err := s.getListPage(ctx, key, startKey, defaultListPageLimit)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
 
-		if len(k) <= i+1 {
-			continue
+		if len(res.Kvs) > 0 {
+			found = true
 		}
+		list = append(list, filterListKeys(key, res.Kvs)...)
 
-		if k[i] != '/' {
-			// We want to ignore all keys that are not separated by slash. When there
-			// is a key stored like "foo/bar/baz", listing keys using "foo/ba" should
-			// not succeed.
-			continue
+		if !res.More {
+			break
 		}
+		startKey = nextListPageKey(res)
+	}
 
-		list = append(list, k[i+1:])
+	if !found {
+		return nil, microerror.Maskf(microstorage.NotFoundError, key)
 	}
 
 	return list, nil
@@ -0,0 +1,141 @@
+package etcdstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"github.com/giantswarm/microerror"
+)
+
+// PutWithTTL stores value at key bound to a lease that expires after ttl
+// unless the key is refreshed or rewritten before then. Callers that need
+// to keep the key alive for longer than ttl should use NewSession instead.
+func (s *Service) PutWithTTL(ctx context.Context, key, value string, ttl time.Duration) error {
+	key, err := s.sanitizeKey(key)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	ttlSeconds, err := leaseTTLSeconds(ttl)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	leaseResp, err := s.etcdClient.Grant(ctx, ttlSeconds)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	_, err = s.keyClient.Put(ctx, key, value, clientv3.WithLease(leaseResp.ID))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// leaseTTLSeconds converts ttl to the whole seconds etcd's lease API
+// expects, rejecting non-positive durations and rounding sub-second
+// durations up to one second so that truncation never silently produces a
+// TTL of 0, which etcd does not treat as "expire immediately".
+func leaseTTLSeconds(ttl time.Duration) (int64, error) {
+	if ttl <= 0 {
+		return 0, microerror.Maskf(invalidTTLError, "ttl must be greater than 0, got %s", ttl)
+	}
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return int64(ttl.Seconds()), nil
+}
+
+// Session represents an etcd lease whose associated key is kept alive in
+// the background until Close or Revoke is called. It is the building block
+// for service registration and ephemeral membership records.
+type Session struct {
+	client  clientv3.Lease
+	leaseID clientv3.LeaseID
+	done    chan struct{}
+	cancel  context.CancelFunc
+}
+
+// NewSession grants a lease with the given ttl, binds key/value to it, and
+// keeps the lease alive in the background until Close or Revoke is called.
+func (s *Service) NewSession(ctx context.Context, key, value string, ttl time.Duration) (*Session, error) {
+	key, err := s.sanitizeKey(key)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	ttlSeconds, err := leaseTTLSeconds(ttl)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	grantCtx, grantCancel := context.WithTimeout(ctx, s.timeout)
+	defer grantCancel()
+
+	leaseResp, err := s.etcdClient.Grant(grantCtx, ttlSeconds)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	_, err = s.keyClient.Put(grantCtx, key, value, clientv3.WithLease(leaseResp.ID))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	keepAliveCtx, cancel := context.WithCancel(context.Background())
+
+	keepAliveChan, err := s.etcdClient.KeepAlive(keepAliveCtx, leaseResp.ID)
+	if err != nil {
+		cancel()
+		return nil, microerror.Mask(err)
+	}
+
+	session := &Session{
+		client:  s.etcdClient,
+		leaseID: leaseResp.ID,
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+	go session.run(keepAliveChan)
+
+	return session, nil
+}
+
+func (sess *Session) run(keepAliveChan <-chan *clientv3.LeaseKeepAliveResponse) {
+	defer close(sess.done)
+	for range keepAliveChan {
+	}
+}
+
+// Done returns a channel that is closed once the keepalive stream ends,
+// either because the lease expired or because Close/Revoke was called.
+// Callers use it to detect that the session's key may no longer exist.
+func (sess *Session) Done() <-chan struct{} {
+	return sess.done
+}
+
+// Revoke revokes the lease immediately, deleting the key bound to it, and
+// stops the keepalive loop.
+func (sess *Session) Revoke(ctx context.Context) error {
+	sess.cancel()
+
+	_, err := sess.client.Revoke(ctx, sess.leaseID)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Close stops the keepalive loop without revoking the lease, letting the
+// bound key expire naturally once the lease's TTL elapses.
+func (sess *Session) Close() {
+	sess.cancel()
+}
@@ -0,0 +1,61 @@
+package etcdstorage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaseTTLSeconds(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ttl         time.Duration
+		expected    int64
+		expectError bool
+	}{
+		{
+			name:        "negative ttl is rejected",
+			ttl:         -time.Second,
+			expectError: true,
+		},
+		{
+			name:        "zero ttl is rejected",
+			ttl:         0,
+			expectError: true,
+		},
+		{
+			name:     "sub-second ttl rounds up to one second",
+			ttl:      100 * time.Millisecond,
+			expected: 1,
+		},
+		{
+			name:     "exact second ttl is unchanged",
+			ttl:      1 * time.Second,
+			expected: 1,
+		},
+		{
+			name:     "multi-second ttl is unchanged",
+			ttl:      5 * time.Second,
+			expected: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seconds, err := leaseTTLSeconds(tc.ttl)
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected error for ttl %s, got none", tc.ttl)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error for ttl %s, got %s", tc.ttl, err)
+			}
+			if seconds != tc.expected {
+				t.Fatalf("expected %d seconds, got %d", tc.expected, seconds)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package etcdstorage
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/microstorage"
+)
+
+// defaultListPageLimit bounds how many keys List and ListStream request per
+// RPC, so iterating a large key space never asks etcd for more than it is
+// willing to return in a single response.
+const defaultListPageLimit = 1000
+
+// ListPage lists up to limit keys directly underneath key, starting at
+// pageToken. The returned nextToken can be passed back in as pageToken to
+// fetch the next page; an empty nextToken means the listing is exhausted.
+// An empty pageToken starts from the beginning.
+func (s *Service) ListPage(ctx context.Context, key string, pageToken string, limit int64) ([]string, string, error) {
+	prefixKey, err := s.sanitizeListKey(key)
+	if err != nil {
+		return nil, "", microerror.Mask(err)
+	}
+
+	startKey := prefixKey
+	if pageToken != "" {
+		startKey = pageToken
+	}
+
+	res, err := s.getListPage(ctx, prefixKey, startKey, limit)
+	if err != nil {
+		return nil, "", microerror.Mask(err)
+	}
+
+	if len(res.Kvs) == 0 && pageToken == "" {
+		return nil, "", microerror.Maskf(microstorage.NotFoundError, key)
+	}
+
+	list := filterListKeys(prefixKey, res.Kvs)
+
+	var nextToken string
+	if res.More {
+		nextToken = nextListPageKey(res)
+	}
+
+	return list, nextToken, nil
+}
+
+// ListStream lists all keys directly underneath key, sending them one at a
+// time on the returned channel while fetching successive pages in the
+// background. At most one error is sent on the error channel before both
+// channels are closed.
+func (s *Service) ListStream(ctx context.Context, key string) (<-chan string, <-chan error) {
+	keys := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(keys)
+		defer close(errs)
+
+		prefixKey, err := s.sanitizeListKey(key)
+		if err != nil {
+			errs <- microerror.Mask(err)
+			return
+		}
+
+		var found bool
+		startKey := prefixKey
+		for {
+			res, err := s.getListPage(ctx, prefixKey, startKey, defaultListPageLimit)
+			if err != nil {
+				errs <- microerror.Mask(err)
+				return
+			}
+
+			if len(res.Kvs) > 0 {
+				found = true
+			}
+
+			for _, k := range filterListKeys(prefixKey, res.Kvs) {
+				select {
+				case keys <- k:
+				case <-ctx.Done():
+					errs <- microerror.Mask(ctx.Err())
+					return
+				}
+			}
+
+			if !res.More {
+				break
+			}
+			startKey = nextListPageKey(res)
+		}
+
+		if !found {
+			errs <- microerror.Maskf(microstorage.NotFoundError, key)
+		}
+	}()
+
+	return keys, errs
+}
+
+// getListPage fetches up to limit keys at or after startKey within the key
+// range covered by prefixKey. It is the shared implementation behind List,
+// ListPage and ListStream, and bounds a single RPC by s.timeout so that
+// listing a large key space isn't forced to complete within one timeout
+// window overall.
+func (s *Service) getListPage(ctx context.Context, prefixKey, startKey string, limit int64) (*clientv3.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	opts := []clientv3.OpOption{
+		clientv3.WithKeysOnly(),
+		clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefixKey)),
+		clientv3.WithLimit(limit),
+	}
+
+	res, err := s.keyClient.Get(ctx, startKey, opts...)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return res, nil
+}
+
+// nextListPageKey computes the token to resume listing after res, the key
+// right after the last key in res.
+func nextListPageKey(res *clientv3.GetResponse) string {
+	return string(res.Kvs[len(res.Kvs)-1].Key) + "\x00"
+}
+
+// filterListKeys extracts the child key names contained in kvs that sit
+// directly beneath prefixKey, preserving the separator-aware rules of the
+// original single-shot List.
+func filterListKeys(prefixKey string, kvs []*mvccpb.KeyValue) []string {
+	// Special case.
+	if prefixKey == "/" {
+		var list []string
+		for _, kv := range kvs {
+			// Skip the leading slash '/'.
+			list = append(list, string(kv.Key)[1:])
+		}
+		return list
+	}
+
+	var list []string
+
+	i := len(prefixKey)
+	for _, kv := range kvs {
+		k := string(kv.Key)
+
+		if len(k) <= i+1 {
+			continue
+		}
+
+		if k[i] != '/' {
+			// We want to ignore all keys that are not separated by slash. When there
+			// is a key stored like "foo/bar/baz", listing keys using "foo/ba" should
+			// not succeed.
+			continue
+		}
+
+		list = append(list, k[i+1:])
+	}
+
+	return list
+}
@@ -0,0 +1,61 @@
+package etcdstorage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+)
+
+func kvsFromKeys(keys ...string) []*mvccpb.KeyValue {
+	var kvs []*mvccpb.KeyValue
+	for _, k := range keys {
+		kvs = append(kvs, &mvccpb.KeyValue{Key: []byte(k)})
+	}
+	return kvs
+}
+
+func TestFilterListKeys_RootSpecialCase(t *testing.T) {
+	kvs := kvsFromKeys("/foo", "/bar")
+
+	list := filterListKeys("/", kvs)
+
+	expected := []string{"foo", "bar"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, list)
+	}
+}
+
+func TestFilterListKeys_NestedKeysAreSeparatorAware(t *testing.T) {
+	// Listing "/foo/ba" must not match "/foo/bar/baz", because "bar" is
+	// not separated from "ba" by a slash. It must match "/foo/ba/qux".
+	kvs := kvsFromKeys("/foo/bar/baz", "/foo/ba/qux", "/foo/ba")
+
+	list := filterListKeys("/foo/ba", kvs)
+
+	expected := []string{"qux"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, list)
+	}
+}
+
+func TestFilterListKeys_MultiPageStitchingMatchesSinglePage(t *testing.T) {
+	allKvs := kvsFromKeys("/foo/a", "/foo/b", "/foo/c", "/foo/d")
+
+	// One page.
+	single := filterListKeys("/foo", allKvs)
+
+	// Two pages stitched back together, as List/ListStream do.
+	var paged []string
+	paged = append(paged, filterListKeys("/foo", allKvs[:2])...)
+	paged = append(paged, filterListKeys("/foo", allKvs[2:])...)
+
+	if !reflect.DeepEqual(single, paged) {
+		t.Fatalf("single-page result %#v does not match stitched pages %#v", single, paged)
+	}
+
+	expected := []string{"a", "b", "c", "d"}
+	if !reflect.DeepEqual(paged, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, paged)
+	}
+}
@@ -0,0 +1,127 @@
+package etcdstorage
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"github.com/giantswarm/microerror"
+)
+
+// TxnBuilder collects a set of puts, deletes and value conditions to commit
+// atomically in a single etcd transaction. Use Service.Txn to create one.
+type TxnBuilder struct {
+	service *Service
+
+	cmps []clientv3.Cmp
+	ops  []clientv3.Op
+	err  error
+}
+
+// Txn starts building a new transaction against the service's keyspace.
+func (s *Service) Txn() *TxnBuilder {
+	return &TxnBuilder{service: s}
+}
+
+// Put adds a put of key/value to the transaction.
+func (t *TxnBuilder) Put(key, value string) *TxnBuilder {
+	key, err := t.service.sanitizeKey(key)
+	if err != nil {
+		t.setErr(err)
+		return t
+	}
+
+	t.ops = append(t.ops, clientv3.OpPut(key, value))
+	return t
+}
+
+// Delete adds a delete of key to the transaction.
+func (t *TxnBuilder) Delete(key string) *TxnBuilder {
+	key, err := t.service.sanitizeKey(key)
+	if err != nil {
+		t.setErr(err)
+		return t
+	}
+
+	t.ops = append(t.ops, clientv3.OpDelete(key))
+	return t
+}
+
+// IfValue adds a precondition that key's current value equals value. The
+// transaction as a whole is only committed if every precondition holds.
+func (t *TxnBuilder) IfValue(key, value string) *TxnBuilder {
+	key, err := t.service.sanitizeKey(key)
+	if err != nil {
+		t.setErr(err)
+		return t
+	}
+
+	t.cmps = append(t.cmps, clientv3.Compare(clientv3.Value(key), "=", value))
+	return t
+}
+
+func (t *TxnBuilder) setErr(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// Commit executes the transaction. If any precondition added via IfValue
+// does not hold, no operation is applied and a compareFailedError (see
+// IsCompareFailed) is returned.
+func (t *TxnBuilder) Commit(ctx context.Context) error {
+	if t.err != nil {
+		return microerror.Mask(t.err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.service.timeout)
+	defer cancel()
+
+	txn := t.service.keyClient.Txn(ctx)
+	if len(t.cmps) > 0 {
+		txn = txn.If(t.cmps...)
+	}
+
+	resp, err := txn.Then(t.ops...).Commit()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !resp.Succeeded {
+		return microerror.Mask(compareFailedError)
+	}
+
+	return nil
+}
+
+// PutMany puts every key/value pair in values in a single etcd
+// transaction, which is significantly faster than issuing one Put per
+// entry and gives all-or-nothing semantics across the batch.
+func (s *Service) PutMany(ctx context.Context, values map[string]string) error {
+	txn := s.Txn()
+	for key, value := range values {
+		txn = txn.Put(key, value)
+	}
+
+	err := txn.Commit(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// DeleteMany deletes every key in keys in a single etcd transaction.
+func (s *Service) DeleteMany(ctx context.Context, keys []string) error {
+	txn := s.Txn()
+	for _, key := range keys {
+		txn = txn.Delete(key)
+	}
+
+	err := txn.Commit(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package etcdstorage
+
+import (
+	"context"
+	"testing"
+)
+
+// TestTxnBuilder_BadKeyShortCircuitsCommit verifies that a sanitizeKey
+// failure recorded by Put/Delete/IfValue is returned by Commit without ever
+// reaching keyClient.Txn, which here would nil-pointer-dereference on a
+// Service built with a nil keyClient if it were called.
+func TestTxnBuilder_BadKeyShortCircuitsCommit(t *testing.T) {
+	s := &Service{}
+
+	txn := s.Txn().Put("", "value")
+
+	err := txn.Commit(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestTxnBuilder_FirstErrorWins(t *testing.T) {
+	s := &Service{}
+
+	txn := &TxnBuilder{service: s}
+	txn.setErr(compareFailedError)
+	txn.setErr(invalidTTLError)
+
+	if txn.err != compareFailedError {
+		t.Fatalf("expected the first error to stick, got %#v", txn.err)
+	}
+}
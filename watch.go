@@ -0,0 +1,91 @@
+package etcdstorage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/giantswarm/microerror"
+)
+
+// EventType describes the kind of change a Watch event represents.
+type EventType int
+
+const (
+	// EventTypePut indicates that the key was created or updated.
+	EventTypePut EventType = iota
+	// EventTypeDelete indicates that the key was deleted.
+	EventTypeDelete
+)
+
+// Event represents a single change observed on a watched key. Key has the
+// service prefix and leading separator stripped, so it has the same form as
+// a key given to Watch or WatchPrefix without a leading separator. For
+// WatchPrefix it is the full key of the changed child, not the prefix.
+type Event struct {
+	Type  EventType
+	Key   string
+	Value string
+}
+
+// Watch watches a single key and emits an Event for every Put or Delete
+// applied to it. The returned channel is closed when ctx is cancelled.
+func (s *Service) Watch(ctx context.Context, key string) (<-chan Event, error) {
+	key, err := s.sanitizeKey(key)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return s.watch(ctx, key), nil
+}
+
+// WatchPrefix watches every key sharing the given prefix and emits an Event
+// for every Put or Delete applied underneath it. The returned channel is
+// closed when ctx is cancelled.
+func (s *Service) WatchPrefix(ctx context.Context, key string) (<-chan Event, error) {
+	key, err := s.sanitizeListKey(key)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return s.watch(ctx, key, clientv3.WithPrefix()), nil
+}
+
+// eventKey strips prefix and the leading separator from the raw etcd key so
+// it matches the key form callers pass to Watch or WatchPrefix.
+func eventKey(prefix, rawKey string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(rawKey, prefix), "/")
+}
+
+func (s *Service) watch(ctx context.Context, key string, opts ...clientv3.OpOption) <-chan Event {
+	events := make(chan Event)
+	watchChan := s.watchClient.Watch(ctx, key, opts...)
+
+	go func() {
+		defer close(events)
+
+		for resp := range watchChan {
+			for _, ev := range resp.Events {
+				event := Event{
+					Key:   eventKey(s.prefix, string(ev.Kv.Key)),
+					Value: string(ev.Kv.Value),
+				}
+				if ev.Type == mvccpb.DELETE {
+					event.Type = EventTypeDelete
+				} else {
+					event.Type = EventTypePut
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
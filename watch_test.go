@@ -0,0 +1,40 @@
+package etcdstorage
+
+import "testing"
+
+func TestEventKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		rawKey   string
+		expected string
+	}{
+		{
+			name:     "key with prefix and separator",
+			prefix:   "/foo",
+			rawKey:   "/foo/bar",
+			expected: "bar",
+		},
+		{
+			name:     "key with no prefix",
+			prefix:   "",
+			rawKey:   "/bar",
+			expected: "bar",
+		},
+		{
+			name:     "root key under prefix",
+			prefix:   "/foo",
+			rawKey:   "/foo/",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := eventKey(tc.prefix, tc.rawKey)
+			if key != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, key)
+			}
+		})
+	}
+}